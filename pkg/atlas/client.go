@@ -0,0 +1,93 @@
+package atlas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mongodb-forks/digest"
+)
+
+// defaultBaseURL is the Atlas Admin API's production base URL.
+const defaultBaseURL = "https://cloud.mongodb.com/api/atlas/v1.0"
+
+// Client is a thin wrapper around the Atlas Admin API, authenticating with
+// HTTP digest auth using a project API key pair.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+}
+
+// NewClient creates a Client authenticated with the given Atlas API key
+// pair.
+func NewClient(publicKey, privateKey string) *Client {
+	transport := digest.NewTransport(publicKey, privateKey)
+	httpClient, _ := transport.Client()
+
+	return &Client{
+		HTTPClient: httpClient,
+		BaseURL:    defaultBaseURL,
+	}
+}
+
+// do issues an authenticated request against the Atlas API, JSON-encoding
+// body (if non-nil) and JSON-decoding the response into out (if non-nil).
+func (c *Client) do(method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return &StatusError{Method: method, Path: path, StatusCode: resp.StatusCode}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// StatusError is returned by Client methods when the Atlas API responds
+// with a non-2xx status, so callers can distinguish e.g. a 404 from a
+// transient 5xx or an auth failure rather than treating every error the
+// same way. Use IsNotFound to check for the former.
+type StatusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("atlas API request %s %s failed with status %d", e.Method, e.Path, e.StatusCode)
+}
+
+// IsNotFound reports whether err is a StatusError for a 404 response, e.g.
+// because the cluster or serverless instance it referred to has already
+// been deleted.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
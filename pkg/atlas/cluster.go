@@ -0,0 +1,74 @@
+package atlas
+
+import "fmt"
+
+// ClusterSpec describes the desired state of an Atlas cluster. It's used
+// both for dedicated clusters and for shared tenant-tier clusters, which go
+// through the same "/clusters" endpoint and differ only in
+// InstanceSizeName (M0/M2/M5 for tenant).
+type ClusterSpec struct {
+	Name             string                  `json:"name"`
+	ProviderSettings ClusterProviderSettings `json:"providerSettings"`
+}
+
+// ClusterProviderSettings selects the backing cloud provider and instance
+// size for a cluster.
+type ClusterProviderSettings struct {
+	ProviderName     string `json:"providerName"`
+	InstanceSizeName string `json:"instanceSizeName"`
+}
+
+// Cluster is the subset of the Atlas cluster resource the broker cares
+// about.
+type Cluster struct {
+	Name              string                   `json:"name"`
+	StateName         string                   `json:"stateName"`
+	ConnectionStrings ClusterConnectionStrings `json:"connectionStrings"`
+}
+
+// ClusterConnectionStrings holds the connection strings Atlas generates for
+// a cluster.
+type ClusterConnectionStrings struct {
+	Standard    string `json:"standard"`
+	StandardSrv string `json:"standardSrv"`
+}
+
+// CreateCluster provisions a new cluster in the given Atlas project.
+func (c *Client) CreateCluster(groupID string, spec ClusterSpec) (*Cluster, error) {
+	var cluster Cluster
+
+	if err := c.do("POST", fmt.Sprintf("/groups/%s/clusters", groupID), spec, &cluster); err != nil {
+		return nil, err
+	}
+
+	return &cluster, nil
+}
+
+// GetCluster fetches a cluster's current state, including its connection
+// strings.
+func (c *Client) GetCluster(groupID, name string) (*Cluster, error) {
+	var cluster Cluster
+
+	if err := c.do("GET", fmt.Sprintf("/groups/%s/clusters/%s", groupID, name), nil, &cluster); err != nil {
+		return nil, err
+	}
+
+	return &cluster, nil
+}
+
+// UpdateCluster applies a plan change (e.g. a new instance size) to an
+// existing cluster.
+func (c *Client) UpdateCluster(groupID, name string, spec ClusterSpec) (*Cluster, error) {
+	var cluster Cluster
+
+	if err := c.do("PATCH", fmt.Sprintf("/groups/%s/clusters/%s", groupID, name), spec, &cluster); err != nil {
+		return nil, err
+	}
+
+	return &cluster, nil
+}
+
+// TerminateCluster deletes a cluster.
+func (c *Client) TerminateCluster(groupID, name string) error {
+	return c.do("DELETE", fmt.Sprintf("/groups/%s/clusters/%s", groupID, name), nil, nil)
+}
@@ -0,0 +1,51 @@
+package atlas
+
+import "fmt"
+
+// DatabaseUserSpec describes the desired state of an Atlas database user.
+// Either Password (SCRAM-SHA-256) or X509Type ("CUSTOMER" to let Atlas issue
+// a certificate) should be set, not both.
+type DatabaseUserSpec struct {
+	Username     string             `json:"username"`
+	Password     string             `json:"password,omitempty"`
+	X509Type     string             `json:"x509Type,omitempty"`
+	DatabaseName string             `json:"databaseName"`
+	Roles        []DatabaseUserRole `json:"roles"`
+}
+
+// DatabaseUserRole grants a role on a database to a database user.
+type DatabaseUserRole struct {
+	RoleName     string `json:"roleName"`
+	DatabaseName string `json:"databaseName"`
+}
+
+// DatabaseUserCertificate is an X.509 certificate and private key Atlas
+// issued for a database user created with X509Type set.
+type DatabaseUserCertificate struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey"`
+}
+
+// CreateDatabaseUser creates a new database user in the given Atlas
+// project.
+func (c *Client) CreateDatabaseUser(groupID string, spec DatabaseUserSpec) error {
+	return c.do("POST", fmt.Sprintf("/groups/%s/databaseUsers", groupID), spec, nil)
+}
+
+// CreateDatabaseUserCertificate creates an X.509 database user and returns
+// the certificate and private key Atlas issued for it.
+func (c *Client) CreateDatabaseUserCertificate(groupID string, spec DatabaseUserSpec) (*DatabaseUserCertificate, error) {
+	spec.X509Type = "CUSTOMER"
+
+	var cert DatabaseUserCertificate
+	if err := c.do("POST", fmt.Sprintf("/groups/%s/databaseUsers", groupID), spec, &cert); err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// DeleteDatabaseUser deletes a database user.
+func (c *Client) DeleteDatabaseUser(groupID, databaseName, username string) error {
+	return c.do("DELETE", fmt.Sprintf("/groups/%s/databaseUsers/%s/%s", groupID, databaseName, username), nil, nil)
+}
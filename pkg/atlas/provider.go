@@ -0,0 +1,43 @@
+package atlas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Provider describes a cloud provider dedicated clusters can be hosted on,
+// along with the instance sizes available there.
+type Provider struct {
+	Name          string
+	InstanceSizes []InstanceSize
+}
+
+// InstanceSize is a single dedicated cluster instance size, e.g. "M10".
+type InstanceSize struct {
+	Name string
+}
+
+// dedicatedInstanceSizes lists the dedicated instance sizes available on
+// each supported provider.
+var dedicatedInstanceSizes = map[string][]string{
+	"AWS":   {"M10", "M20", "M30", "M40", "M50", "M60"},
+	"GCP":   {"M10", "M20", "M30", "M40", "M50"},
+	"AZURE": {"M10", "M20", "M30", "M40"},
+}
+
+// GetProvider returns the dedicated instance sizes available for a cloud
+// provider. The sizes come from the fixed dedicatedInstanceSizes table; no
+// Atlas API request is made.
+func (c *Client) GetProvider(name string) (*Provider, error) {
+	sizes, ok := dedicatedInstanceSizes[strings.ToUpper(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+
+	instanceSizes := make([]InstanceSize, len(sizes))
+	for i, size := range sizes {
+		instanceSizes[i] = InstanceSize{Name: size}
+	}
+
+	return &Provider{Name: name, InstanceSizes: instanceSizes}, nil
+}
@@ -0,0 +1,55 @@
+package atlas
+
+import "fmt"
+
+// ServerlessInstanceSpec describes the desired state of an Atlas serverless
+// instance, provisioned through the "/serverless" endpoint rather than
+// "/clusters".
+type ServerlessInstanceSpec struct {
+	Name             string                     `json:"name"`
+	ProviderSettings ServerlessProviderSettings `json:"providerSettings"`
+}
+
+// ServerlessProviderSettings selects the backing cloud provider a serverless
+// instance runs on. It has no region field, so no region is sent to Atlas.
+type ServerlessProviderSettings struct {
+	ProviderName        string `json:"providerName"`
+	BackingProviderName string `json:"backingProviderName"`
+}
+
+// ServerlessInstance is the subset of the Atlas serverless instance resource
+// the broker cares about.
+type ServerlessInstance struct {
+	Name              string                   `json:"name"`
+	StateName         string                   `json:"stateName"`
+	ConnectionStrings ClusterConnectionStrings `json:"connectionStrings"`
+}
+
+// CreateServerlessInstance provisions a new serverless instance in the
+// given Atlas project.
+func (c *Client) CreateServerlessInstance(groupID string, spec ServerlessInstanceSpec) (*ServerlessInstance, error) {
+	var instance ServerlessInstance
+
+	if err := c.do("POST", fmt.Sprintf("/groups/%s/serverless", groupID), spec, &instance); err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+// GetServerlessInstance fetches a serverless instance's current state,
+// including its connection strings.
+func (c *Client) GetServerlessInstance(groupID, name string) (*ServerlessInstance, error) {
+	var instance ServerlessInstance
+
+	if err := c.do("GET", fmt.Sprintf("/groups/%s/serverless/%s", groupID, name), nil, &instance); err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+// TerminateServerlessInstance deletes a serverless instance.
+func (c *Client) TerminateServerlessInstance(groupID, name string) error {
+	return c.do("DELETE", fmt.Sprintf("/groups/%s/serverless/%s", groupID, name), nil, nil)
+}
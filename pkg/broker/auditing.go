@@ -0,0 +1,96 @@
+package broker
+
+import (
+	"errors"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+)
+
+// auditingSchema describes the "auditing" provision/update parameter
+// accepted by every service plan, covering the audit filter JSON,
+// auditAuthorizationSuccess and enabled flag understood by Atlas' project
+// auditing API. Atlas itself is the source of truth for audit filter
+// validity, so the schema doesn't attempt to validate the filter's contents.
+func auditingSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"auditing": map[string]interface{}{
+				"type":        "object",
+				"description": "Atlas project database auditing configuration",
+				"properties": map[string]interface{}{
+					"enabled": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether database auditing is enabled for the project",
+					},
+					"auditFilter": map[string]interface{}{
+						"type":        "string",
+						"description": "JSON audit filter, validated by Atlas",
+					},
+					"auditAuthorizationSuccess": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether successful authorization attempts are audited",
+					},
+				},
+			},
+		},
+	}
+}
+
+// auditingSpecFromParams extracts the desired AuditingSpec from a set of raw
+// provision or update parameters, returning nil if auditing wasn't
+// configured in the request.
+func auditingSpecFromParams(params map[string]interface{}) (*atlas.AuditingSpec, error) {
+	raw, ok := params["auditing"]
+	if !ok {
+		return nil, nil
+	}
+
+	auditingParams, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("auditing parameter must be an object")
+	}
+
+	var spec atlas.AuditingSpec
+
+	if enabled, ok := auditingParams["enabled"].(bool); ok {
+		spec.Enabled = enabled
+	}
+
+	if filter, ok := auditingParams["auditFilter"].(string); ok {
+		spec.AuditFilter = filter
+	}
+
+	if success, ok := auditingParams["auditAuthorizationSuccess"].(bool); ok {
+		spec.AuditAuthorizationSuccess = success
+	}
+
+	return &spec, nil
+}
+
+// applyAuditing applies the desired auditing configuration to an Atlas
+// project and returns a rollback function that restores the previous
+// configuration. Callers should invoke the rollback if a later step of the
+// provision/update they're part of fails, so auditing is never left
+// half-applied. If spec is nil, applyAuditing is a no-op.
+func (b Broker) applyAuditing(groupID string, spec *atlas.AuditingSpec) (rollback func() error, err error) {
+	noop := func() error { return nil }
+
+	if spec == nil {
+		return noop, nil
+	}
+
+	previous, err := b.atlas.GetAuditing(groupID)
+	if err != nil {
+		return noop, err
+	}
+
+	if err := b.atlas.SetAuditing(groupID, *spec); err != nil {
+		return noop, err
+	}
+
+	return func() error {
+		return b.atlas.SetAuditing(groupID, *previous)
+	}, nil
+}
@@ -0,0 +1,49 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+)
+
+func TestAuditingSpecFromParamsAbsent(t *testing.T) {
+	spec, err := auditingSpecFromParams(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("auditingSpecFromParams returned unexpected error: %v", err)
+	}
+
+	if spec != nil {
+		t.Fatalf("auditingSpecFromParams() = %v, want nil", spec)
+	}
+}
+
+func TestAuditingSpecFromParams(t *testing.T) {
+	params := map[string]interface{}{
+		"auditing": map[string]interface{}{
+			"enabled":                   true,
+			"auditFilter":               `{"atype": "authenticate"}`,
+			"auditAuthorizationSuccess": true,
+		},
+	}
+
+	spec, err := auditingSpecFromParams(params)
+	if err != nil {
+		t.Fatalf("auditingSpecFromParams returned unexpected error: %v", err)
+	}
+
+	want := &atlas.AuditingSpec{
+		Enabled:                   true,
+		AuditFilter:               `{"atype": "authenticate"}`,
+		AuditAuthorizationSuccess: true,
+	}
+
+	if *spec != *want {
+		t.Fatalf("auditingSpecFromParams() = %+v, want %+v", spec, want)
+	}
+}
+
+func TestAuditingSpecFromParamsInvalidType(t *testing.T) {
+	if _, err := auditingSpecFromParams(map[string]interface{}{"auditing": "not an object"}); err == nil {
+		t.Fatal("auditingSpecFromParams(auditing: string) = nil error, want an error")
+	}
+}
@@ -0,0 +1,173 @@
+package broker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+)
+
+// bindDatabaseName is the database the generated bind-time user is scoped
+// to. It's the same database every binding authenticates against; access to
+// individual application databases is controlled by the granted role.
+const bindDatabaseName = "admin"
+
+// The auth modes a caller can request via the "authMode" bind parameter.
+const (
+	authModeSCRAM = "scram-sha-256"
+	authModeX509  = "x509"
+)
+
+// Bind creates a new Atlas database user scoped to bindingID and returns
+// its credentials in the Credentials map. The user authenticates with
+// SCRAM-SHA-256 by default, or with an Atlas-issued X.509 certificate if
+// the bind parameters set "authMode" to "x509". If the bind parameters
+// include a connectionSecret, the same credentials and connection strings
+// are also written to a Kubernetes Secret so a caller that can't read the
+// bind response (e.g. a controller) can consume them.
+func (b Broker) Bind(ctx context.Context, instanceID, bindingID string, details brokerapi.BindDetails, asyncAllowed bool) (brokerapi.Binding, error) {
+	params, err := decodeParams(details.RawParameters)
+	if err != nil {
+		return brokerapi.Binding{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-parameters")
+	}
+
+	authMode, err := authModeFromBindParams(params)
+	if err != nil {
+		return brokerapi.Binding{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-auth-mode")
+	}
+
+	secretParams, err := connectionSecretParamsFromBindParams(params)
+	if err != nil {
+		return brokerapi.Binding{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-connection-secret-parameters")
+	}
+
+	data, err := b.createBindUser(bindingID, authMode)
+	if err != nil {
+		return brokerapi.Binding{}, err
+	}
+
+	var connectionStrings atlas.ClusterConnectionStrings
+
+	if ServiceClassKindForID(details.ServiceID) == ServiceClassServerless {
+		instance, err := b.atlas.GetServerlessInstance(b.groupID, atlasNameForInstance(instanceID))
+		if err != nil {
+			return brokerapi.Binding{}, err
+		}
+
+		connectionStrings = instance.ConnectionStrings
+	} else {
+		cluster, err := b.atlas.GetCluster(b.groupID, atlasNameForInstance(instanceID))
+		if err != nil {
+			return brokerapi.Binding{}, err
+		}
+
+		connectionStrings = cluster.ConnectionStrings
+	}
+
+	data.ConnectionStringStandard = connectionStrings.Standard
+	data.ConnectionStringSRV = connectionStrings.StandardSrv
+
+	if secretParams != nil {
+		if b.kubeClient == nil {
+			return brokerapi.Binding{}, brokerapi.NewFailureResponse(
+				errConnectionSecretUnsupported, http.StatusBadRequest, "connection-secret-unsupported")
+		}
+
+		if err := writeConnectionSecret(b.kubeClient, b.defaultNamespace, *secretParams, *data); err != nil {
+			return brokerapi.Binding{}, err
+		}
+	}
+
+	credentials := map[string]interface{}{
+		"connectionStringStandard": data.ConnectionStringStandard,
+		"connectionStringSrv":      data.ConnectionStringSRV,
+	}
+
+	if authMode == authModeX509 {
+		credentials["certificate"] = data.Certificate
+		credentials["privateKey"] = data.PrivateKey
+	} else {
+		credentials["username"] = data.Username
+		credentials["password"] = data.Password
+	}
+
+	return brokerapi.Binding{Credentials: credentials}, nil
+}
+
+// createBindUser creates the Atlas database user backing a binding, using
+// the requested auth mode, and returns the credentials it was issued.
+func (b Broker) createBindUser(bindingID, authMode string) (*ConnectionSecretData, error) {
+	username := bindingID
+	roles := []atlas.DatabaseUserRole{
+		{RoleName: "readWriteAnyDatabase", DatabaseName: bindDatabaseName},
+	}
+
+	if authMode == authModeX509 {
+		cert, err := b.atlas.CreateDatabaseUserCertificate(b.groupID, atlas.DatabaseUserSpec{
+			Username:     username,
+			DatabaseName: bindDatabaseName,
+			Roles:        roles,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &ConnectionSecretData{
+			Username:    username,
+			Certificate: cert.Certificate,
+			PrivateKey:  cert.PrivateKey,
+		}, nil
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.atlas.CreateDatabaseUser(b.groupID, atlas.DatabaseUserSpec{
+		Username:     username,
+		Password:     password,
+		DatabaseName: bindDatabaseName,
+		Roles:        roles,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ConnectionSecretData{Username: username, Password: password}, nil
+}
+
+// authModeFromBindParams extracts the requested "authMode" bind parameter,
+// defaulting to SCRAM-SHA-256 when it's absent.
+func authModeFromBindParams(params map[string]interface{}) (string, error) {
+	raw, ok := params["authMode"]
+	if !ok {
+		return authModeSCRAM, nil
+	}
+
+	authMode, ok := raw.(string)
+	if !ok {
+		return "", errors.New("authMode parameter must be a string")
+	}
+
+	switch authMode {
+	case authModeSCRAM, authModeX509:
+		return authMode, nil
+	default:
+		return "", errors.New(`authMode must be "scram-sha-256" or "x509"`)
+	}
+}
+
+// generatePassword returns a random password suitable for a SCRAM-SHA-256
+// database user.
+func generatePassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,35 @@
+package broker
+
+import "testing"
+
+func TestAuthModeFromBindParamsDefault(t *testing.T) {
+	authMode, err := authModeFromBindParams(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("authModeFromBindParams returned unexpected error: %v", err)
+	}
+
+	if authMode != authModeSCRAM {
+		t.Fatalf("authModeFromBindParams() = %q, want %q", authMode, authModeSCRAM)
+	}
+}
+
+func TestAuthModeFromBindParamsX509(t *testing.T) {
+	authMode, err := authModeFromBindParams(map[string]interface{}{"authMode": "x509"})
+	if err != nil {
+		t.Fatalf("authModeFromBindParams returned unexpected error: %v", err)
+	}
+
+	if authMode != authModeX509 {
+		t.Fatalf("authModeFromBindParams() = %q, want %q", authMode, authModeX509)
+	}
+}
+
+func TestAuthModeFromBindParamsInvalid(t *testing.T) {
+	if _, err := authModeFromBindParams(map[string]interface{}{"authMode": "kerberos"}); err == nil {
+		t.Fatal("authModeFromBindParams(kerberos) = nil error, want an error")
+	}
+
+	if _, err := authModeFromBindParams(map[string]interface{}{"authMode": 5}); err == nil {
+		t.Fatal("authModeFromBindParams(authMode: 5) = nil error, want an error")
+	}
+}
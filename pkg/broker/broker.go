@@ -0,0 +1,72 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/broker/catalogcache"
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/broker/instancestore"
+	"github.com/pivotal-cf/brokerapi"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Broker implements brokerapi.ServiceBroker, translating Open Service
+// Broker API calls into Atlas API calls for a single Atlas project.
+type Broker struct {
+	atlas   *atlas.Client
+	groupID string
+	logger  *zap.SugaredLogger
+
+	kubeClient       kubernetes.Interface
+	defaultNamespace string
+
+	catalogCache  *catalogcache.Cache
+	instanceStore InstanceStore
+	registry      *catalogRegistry
+}
+
+// NewBroker builds a Broker for a single Atlas project. If cacheTTL is
+// positive, Services() and findProviderAndInstanceSizeByIDs are served from
+// a background-refreshed catalogcache.Cache instead of calling Atlas
+// directly on every request. Provisioned instances are tracked in an
+// in-memory instancestore.Store by default; call WithInstanceStore to back
+// it with something durable instead.
+func NewBroker(atlasClient *atlas.Client, groupID string, logger *zap.SugaredLogger, cacheTTL time.Duration) Broker {
+	b := Broker{
+		atlas:         atlasClient,
+		groupID:       groupID,
+		logger:        logger,
+		instanceStore: instancestore.New(),
+		registry:      &catalogRegistry{planIDs: make(map[string]string)},
+	}
+
+	if cacheTTL > 0 {
+		b.catalogCache = catalogcache.New(func() ([]brokerapi.Service, map[string]catalogcache.PlanIndexEntry, error) {
+			return b.refreshCatalogFromAtlas(context.Background())
+		}, cacheTTL, logger)
+	}
+
+	return b
+}
+
+// WithKubeClient returns a copy of b that writes bind-time connection
+// secrets (see connection_secret.go) into the given Kubernetes cluster,
+// falling back to defaultNamespace when a bind request doesn't specify one.
+func (b Broker) WithKubeClient(kubeClient kubernetes.Interface, defaultNamespace string) Broker {
+	b.kubeClient = kubeClient
+	b.defaultNamespace = defaultNamespace
+
+	return b
+}
+
+// WithInstanceStore returns a copy of b that records provisioned instances
+// into store instead of the default in-memory instancestore.Store,
+// consulting it when deciding whether a plan dropped from the catalog is
+// safe to prune (see pruneStaleCatalogEntries).
+func (b Broker) WithInstanceStore(store InstanceStore) Broker {
+	b.instanceStore = store
+
+	return b
+}
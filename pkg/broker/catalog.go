@@ -5,36 +5,108 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/broker/catalogcache"
 	"github.com/pivotal-cf/brokerapi"
 )
 
 // idPrefix will be prepended to service and plan IDs to ensure their uniqueness.
 const idPrefix = "aosb-cluster"
 
+// serverlessIDPrefix and tenantIDPrefix are prepended to the service and
+// plan IDs generated for Atlas serverless instances and shared tenant-tier
+// clusters respectively, keeping them distinct from dedicated cluster IDs.
+const (
+	serverlessIDPrefix = "aosb-serverless"
+	tenantIDPrefix     = "aosb-tenant"
+)
+
 // providerNames contains all the available cloud providers on which clusters
 // may be provisioned. The available instance sizes for each provider are
 // fetched dynamically from the Atlas API.
 var providerNames = []string{"AWS", "GCP", "AZURE"}
 
+// tenantInstanceSizes contains the instance sizes available on Atlas' shared
+// tenant tier. Unlike the dedicated instance sizes returned by
+// atlas.Client.GetProvider, these are the same across all providers.
+var tenantInstanceSizes = []string{"M0", "M2", "M5"}
+
+// ServiceClassKind identifies which family of Atlas deployment a service
+// class maps onto, so the provision, bind, update and deprovision handlers
+// know which Atlas endpoint ("/clusters" vs "/serverless") to call.
+type ServiceClassKind int
+
+// The kinds of Atlas deployments the broker can provision.
+const (
+	ServiceClassDedicated ServiceClassKind = iota
+	ServiceClassServerless
+	ServiceClassTenant
+)
+
+// ServiceClassKindForID resolves which kind of Atlas deployment a service ID
+// refers to.
+func ServiceClassKindForID(serviceID string) ServiceClassKind {
+	switch {
+	case strings.HasPrefix(serviceID, serverlessIDPrefix):
+		return ServiceClassServerless
+	case strings.HasPrefix(serviceID, tenantIDPrefix):
+		return ServiceClassTenant
+	default:
+		return ServiceClassDedicated
+	}
+}
+
 // Services generates the service catalog which will be presented to consumers of the API.
+//
+// When b.catalogCache is set and has completed a refresh, this is served
+// from the cache - the cache's own background goroutine is what calls
+// refreshCatalogFromAtlas. Until the cache is warm, or without a cache,
+// refreshCatalogFromAtlas is called directly. This matters because Services
+// is not only called by the service-catalog controller's periodic catalog
+// poll, but, via ValidateServiceAndPlanIDs, on every provision and update
+// request.
 func (b Broker) Services(ctx context.Context) ([]brokerapi.Service, error) {
 	b.logger.Info("Retrieving service catalog")
 
-	services := make([]brokerapi.Service, len(providerNames))
+	if b.catalogCache != nil {
+		if services, err := b.catalogCache.Services(); err == nil {
+			return services, nil
+		}
+	}
+
+	services, _, err := b.refreshCatalogFromAtlas(ctx)
+	return services, err
+}
 
-	for i, providerName := range providerNames {
+// refreshCatalogFromAtlas builds the current catalog from b.atlas, along
+// with an index resolving dedicated cluster plan IDs back to their
+// provider and instance size. It's the RefreshFunc passed to
+// catalogcache.New.
+func (b Broker) refreshCatalogFromAtlas(ctx context.Context) ([]brokerapi.Service, map[string]catalogcache.PlanIndexEntry, error) {
+	var services []brokerapi.Service
+	planIndex := make(map[string]catalogcache.PlanIndexEntry)
+
+	for _, providerName := range providerNames {
 		provider, err := b.atlas.GetProvider(providerName)
 		if err != nil {
-			return services, err
+			return services, planIndex, err
 		}
 
 		// Create a CLI-friendly and user-friendly name. Will be displayed in the
 		// marketplace generated by the service catalog.
 		catalogName := fmt.Sprintf("mongodb-atlas-%s", strings.ToLower(provider.Name))
 
-		services[i] = brokerapi.Service{
+		for _, instanceSize := range provider.InstanceSizes {
+			instanceSize := instanceSize
+			planIndex[planIDForInstanceSize(provider, instanceSize)] = catalogcache.PlanIndexEntry{
+				Provider:     provider,
+				InstanceSize: &instanceSize,
+			}
+		}
+
+		services = append(services, brokerapi.Service{
 			ID:                   serviceIDForProvider(provider),
 			Name:                 catalogName,
 			Description:          fmt.Sprintf(`Atlas cluster hosted on "%s"`, provider.Name),
@@ -44,15 +116,154 @@ func (b Broker) Services(ctx context.Context) ([]brokerapi.Service, error) {
 			Metadata:             nil,
 			PlanUpdatable:        true,
 			Plans:                plansForProvider(provider),
+		})
+
+		services = append(services, brokerapi.Service{
+			ID:                   serverlessServiceIDForProvider(providerName),
+			Name:                 fmt.Sprintf("mongodb-atlas-serverless-%s", strings.ToLower(providerName)),
+			Description:          fmt.Sprintf(`Atlas serverless instance hosted on "%s"`, providerName),
+			Bindable:             true,
+			InstancesRetrievable: false,
+			BindingsRetrievable:  false,
+			Metadata:             nil,
+			PlanUpdatable:        false,
+			Plans:                plansForServerless(providerName),
+		})
+
+		services = append(services, brokerapi.Service{
+			ID:                   tenantServiceIDForProvider(providerName),
+			Name:                 fmt.Sprintf("mongodb-atlas-tenant-%s", strings.ToLower(providerName)),
+			Description:          fmt.Sprintf(`Atlas shared tenant-tier cluster hosted on "%s"`, providerName),
+			Bindable:             true,
+			InstancesRetrievable: false,
+			BindingsRetrievable:  false,
+			Metadata:             nil,
+			PlanUpdatable:        true,
+			Plans:                plansForTenant(providerName),
+		})
+	}
+
+	if b.instanceStore != nil {
+		b.pruneStaleCatalogEntries(ctx, services, b.instanceStore)
+	}
+
+	return services, planIndex, nil
+}
+
+// ErrInvalidCatalogReference is returned when a service or plan ID doesn't
+// correspond to any entry in the current catalog.
+var ErrInvalidCatalogReference = errors.New("service or plan ID not found in the current catalog")
+
+// ValidateServiceAndPlanIDs checks that the given service and plan IDs are
+// present in the catalog Services would currently generate. Provision and
+// plan-update handlers call this before touching Atlas, so a stale
+// reference - e.g. a plan whose instance size Atlas has stopped advertising,
+// or one removed in a previous catalog refresh - is rejected as a clean 400
+// instead of surfacing as a downstream Atlas error.
+func (b Broker) ValidateServiceAndPlanIDs(ctx context.Context, serviceID, planID string) error {
+	services, err := b.Services(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		if service.ID != serviceID {
+			continue
+		}
+
+		for _, plan := range service.Plans {
+			if plan.ID == planID {
+				return nil
+			}
+		}
+	}
+
+	return ErrInvalidCatalogReference
+}
+
+// InstanceCounter reports how many provisioned instances currently reference
+// a given service and plan ID. It's backed by whatever store the broker uses
+// to track provisioned instances.
+type InstanceCounter interface {
+	CountInstancesForPlan(serviceID, planID string) (int, error)
+}
+
+// InstanceStore tracks which service and plan ID a provisioned instance was
+// created against, so pruneStaleCatalogEntries can tell whether a plan
+// dropped from the catalog is still in use. Broker.Provision/Deprovision
+// call Record/Remove to keep it up to date; see pkg/broker/instancestore
+// for the default in-memory implementation.
+type InstanceStore interface {
+	InstanceCounter
+
+	Record(instanceID, serviceID, planID string)
+	Remove(instanceID string)
+}
+
+// catalogRegistry remembers the plan IDs returned by the previous catalog
+// refresh, so the next refresh can tell which ones have disappeared. It's
+// owned by a single Broker (via Broker.registry) rather than being a
+// package-level global, so multiple Broker instances in the same process
+// (e.g. one per contract test harness) don't share and corrupt each other's
+// view of the catalog.
+type catalogRegistry struct {
+	mu      sync.Mutex
+	planIDs map[string]string // planID -> serviceID
+}
+
+// pruneStaleCatalogEntries compares the newly generated catalog against the
+// previous refresh. A plan that's disappeared - e.g. its provider was
+// dropped from providerNames or Atlas stopped advertising its instance size -
+// is only safe to garbage-collect once nothing is still provisioned against
+// it, following the approach used by kubernetes-incubator/service-catalog
+// for removed classes and plans. Until then we log a warning so an operator
+// can migrate the remaining instances off it; the service-catalog controller
+// itself takes care of marking the corresponding ServiceClass/ServicePlan as
+// removed once the broker stops returning it.
+func (b Broker) pruneStaleCatalogEntries(ctx context.Context, services []brokerapi.Service, counter InstanceCounter) {
+	b.registry.mu.Lock()
+	defer b.registry.mu.Unlock()
+
+	current := make(map[string]string)
+	for _, service := range services {
+		for _, plan := range service.Plans {
+			current[plan.ID] = service.ID
+		}
+	}
+
+	for planID, serviceID := range b.registry.planIDs {
+		if _, stillPresent := current[planID]; stillPresent {
+			continue
+		}
+
+		count, err := counter.CountInstancesForPlan(serviceID, planID)
+		if err != nil {
+			b.logger.Errorw("Failed to check instance count for removed plan", "planID", planID, "error", err)
+			continue
+		}
+
+		if count > 0 {
+			b.logger.Warnw("Plan removed from catalog but still referenced by provisioned instances", "planID", planID, "serviceID", serviceID, "instanceCount", count)
+		} else {
+			b.logger.Infow("Pruned stale plan with no remaining instances", "planID", planID, "serviceID", serviceID)
 		}
 	}
 
-	return services, nil
+	b.registry.planIDs = current
 }
 
 // findProviderAndInstanceSizeByIDs will search all available providers and
 // instance sizes to find the ones matching the specified service and plan ID.
+// It only resolves dedicated cluster service classes; serverless and tenant
+// classes are resolved through findServerlessProviderByIDs and
+// findTenantInstanceSizeByIDs respectively.
 func (b Broker) findProviderAndInstanceSizeByIDs(serviceID, planID string) (*atlas.Provider, *atlas.InstanceSize, error) {
+	if b.catalogCache != nil {
+		if provider, instanceSize, ok := b.catalogCache.Lookup(planID); ok && serviceIDForProvider(provider) == serviceID {
+			return provider, instanceSize, nil
+		}
+	}
+
 	for _, providerName := range providerNames {
 		provider, err := b.atlas.GetProvider(providerName)
 		if err != nil {
@@ -71,6 +282,36 @@ func (b Broker) findProviderAndInstanceSizeByIDs(serviceID, planID string) (*atl
 	return nil, nil, errors.New("invalid service ID or plan ID")
 }
 
+// findServerlessProviderByIDs resolves the backing cloud provider for a
+// serverless service and plan ID pair.
+func (b Broker) findServerlessProviderByIDs(serviceID, planID string) (string, error) {
+	for _, providerName := range providerNames {
+		if serverlessServiceIDForProvider(providerName) == serviceID && serverlessPlanIDForProvider(providerName) == planID {
+			return providerName, nil
+		}
+	}
+
+	return "", errors.New("invalid service ID or plan ID")
+}
+
+// findTenantInstanceSizeByIDs resolves the backing cloud provider and shared
+// tier instance size for a tenant service and plan ID pair.
+func (b Broker) findTenantInstanceSizeByIDs(serviceID, planID string) (string, string, error) {
+	for _, providerName := range providerNames {
+		if tenantServiceIDForProvider(providerName) != serviceID {
+			continue
+		}
+
+		for _, instanceSize := range tenantInstanceSizes {
+			if tenantPlanIDForInstanceSize(providerName, instanceSize) == planID {
+				return providerName, instanceSize, nil
+			}
+		}
+	}
+
+	return "", "", errors.New("invalid service ID or plan ID")
+}
+
 // plansForProvider will convert the available instance sizes for a provider
 // to service plans for the broker.
 func plansForProvider(provider *atlas.Provider) []brokerapi.ServicePlan {
@@ -81,6 +322,12 @@ func plansForProvider(provider *atlas.Provider) []brokerapi.ServicePlan {
 			ID:          planIDForInstanceSize(provider, instanceSize),
 			Name:        instanceSize.Name,
 			Description: fmt.Sprintf("Instance size \"%s\"", instanceSize.Name),
+			Schemas: &brokerapi.ServiceSchemas{
+				Instance: brokerapi.ServiceInstanceSchema{
+					Create: brokerapi.Schema{Parameters: auditingSchema()},
+					Update: brokerapi.Schema{Parameters: auditingSchema()},
+				},
+			},
 		}
 
 		plans = append(plans, plan)
@@ -99,3 +346,69 @@ func serviceIDForProvider(provider *atlas.Provider) string {
 func planIDForInstanceSize(provider *atlas.Provider, instanceSize atlas.InstanceSize) string {
 	return fmt.Sprintf("%s-plan-%s-%s", idPrefix, strings.ToLower(provider.Name), strings.ToLower(instanceSize.Name))
 }
+
+// plansForServerless generates the single service plan offered for a
+// serverless instance on a given provider. Serverless instances don't have
+// instance sizes like dedicated clusters; Atlas scales them automatically
+// within the backing provider's region group.
+func plansForServerless(providerName string) []brokerapi.ServicePlan {
+	return []brokerapi.ServicePlan{
+		{
+			ID:          serverlessPlanIDForProvider(providerName),
+			Name:        "serverless",
+			Description: fmt.Sprintf(`Serverless instance backed by "%s"`, providerName),
+			Schemas: &brokerapi.ServiceSchemas{
+				Instance: brokerapi.ServiceInstanceSchema{
+					Create: brokerapi.Schema{Parameters: auditingSchema()},
+					Update: brokerapi.Schema{Parameters: auditingSchema()},
+				},
+			},
+		},
+	}
+}
+
+// serverlessServiceIDForProvider will generate a globally unique ID for a
+// serverless service class on a given provider.
+func serverlessServiceIDForProvider(providerName string) string {
+	return fmt.Sprintf("%s-service-%s", serverlessIDPrefix, strings.ToLower(providerName))
+}
+
+// serverlessPlanIDForProvider will generate a globally unique ID for the
+// serverless plan on a given provider.
+func serverlessPlanIDForProvider(providerName string) string {
+	return fmt.Sprintf("%s-plan-%s", serverlessIDPrefix, strings.ToLower(providerName))
+}
+
+// plansForTenant converts the shared tenant tier instance sizes into service
+// plans for a given provider.
+func plansForTenant(providerName string) []brokerapi.ServicePlan {
+	plans := make([]brokerapi.ServicePlan, len(tenantInstanceSizes))
+
+	for i, instanceSize := range tenantInstanceSizes {
+		plans[i] = brokerapi.ServicePlan{
+			ID:          tenantPlanIDForInstanceSize(providerName, instanceSize),
+			Name:        instanceSize,
+			Description: fmt.Sprintf("Shared tenant instance size \"%s\"", instanceSize),
+			Schemas: &brokerapi.ServiceSchemas{
+				Instance: brokerapi.ServiceInstanceSchema{
+					Create: brokerapi.Schema{Parameters: auditingSchema()},
+					Update: brokerapi.Schema{Parameters: auditingSchema()},
+				},
+			},
+		}
+	}
+
+	return plans
+}
+
+// tenantServiceIDForProvider will generate a globally unique ID for a tenant
+// service class on a given provider.
+func tenantServiceIDForProvider(providerName string) string {
+	return fmt.Sprintf("%s-service-%s", tenantIDPrefix, strings.ToLower(providerName))
+}
+
+// tenantPlanIDForInstanceSize will generate a globally unique ID for a shared
+// tenant tier instance size on a given provider.
+func tenantPlanIDForInstanceSize(providerName, instanceSize string) string {
+	return fmt.Sprintf("%s-plan-%s-%s", tenantIDPrefix, strings.ToLower(providerName), strings.ToLower(instanceSize))
+}
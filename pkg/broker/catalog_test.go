@@ -0,0 +1,83 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+	"go.uber.org/zap"
+)
+
+func TestValidateServiceAndPlanIDs(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker(&atlas.Client{}, "group-id", zap.NewNop().Sugar(), 0)
+
+	services, err := b.Services(ctx)
+	if err != nil {
+		t.Fatalf("Services() returned unexpected error: %v", err)
+	}
+
+	if len(services) == 0 || len(services[0].Plans) == 0 {
+		t.Fatal("expected at least one service with at least one plan")
+	}
+
+	service, plan := services[0], services[0].Plans[0]
+
+	if err := b.ValidateServiceAndPlanIDs(ctx, service.ID, plan.ID); err != nil {
+		t.Fatalf("ValidateServiceAndPlanIDs(%q, %q) returned unexpected error: %v", service.ID, plan.ID, err)
+	}
+
+	if err := b.ValidateServiceAndPlanIDs(ctx, "unknown-service", "unknown-plan"); err != ErrInvalidCatalogReference {
+		t.Fatalf("ValidateServiceAndPlanIDs(unknown, unknown) = %v, want ErrInvalidCatalogReference", err)
+	}
+}
+
+// fakeInstanceCounter records the (serviceID, planID) pairs it's asked
+// about and always reports count back.
+type fakeInstanceCounter struct {
+	count int
+	calls []string
+}
+
+func (f *fakeInstanceCounter) CountInstancesForPlan(serviceID, planID string) (int, error) {
+	f.calls = append(f.calls, serviceID+"/"+planID)
+	return f.count, nil
+}
+
+func TestPruneStaleCatalogEntriesRemovesUnreferencedPlan(t *testing.T) {
+	b := NewBroker(&atlas.Client{}, "group-id", zap.NewNop().Sugar(), 0)
+	b.registry.planIDs = map[string]string{"stale-plan": "stale-service"}
+
+	counter := &fakeInstanceCounter{count: 0}
+
+	b.pruneStaleCatalogEntries(context.Background(), []brokerapi.Service{}, counter)
+
+	if len(counter.calls) != 1 || counter.calls[0] != "stale-service/stale-plan" {
+		t.Fatalf("counter.calls = %v, want a single call for stale-service/stale-plan", counter.calls)
+	}
+
+	if len(b.registry.planIDs) != 0 {
+		t.Fatalf("registry.planIDs = %v, want empty after pruning", b.registry.planIDs)
+	}
+}
+
+func TestPruneStaleCatalogEntriesKeepsCurrentPlans(t *testing.T) {
+	b := NewBroker(&atlas.Client{}, "group-id", zap.NewNop().Sugar(), 0)
+	b.registry.planIDs = map[string]string{}
+
+	services := []brokerapi.Service{
+		{ID: "service-1", Plans: []brokerapi.ServicePlan{{ID: "plan-1"}}},
+	}
+
+	counter := &fakeInstanceCounter{}
+	b.pruneStaleCatalogEntries(context.Background(), services, counter)
+
+	if len(counter.calls) != 0 {
+		t.Fatalf("counter.calls = %v, want no calls since nothing is stale", counter.calls)
+	}
+
+	if b.registry.planIDs["plan-1"] != "service-1" {
+		t.Fatalf("registry.planIDs = %v, want plan-1 -> service-1", b.registry.planIDs)
+	}
+}
@@ -0,0 +1,168 @@
+// Package catalogcache holds the catalog the broker generates from Atlas
+// between background refreshes, so Services() and plan lookups can be
+// served synchronously instead of calling Atlas on every service-catalog
+// poll or provision/update/deprovision request.
+package catalogcache
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultRefreshInterval is how often the cache refreshes its contents in
+// the background when the broker doesn't configure one explicitly.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// PlanIndexEntry resolves a plan ID back to the provider and instance size
+// it was generated from, so a Broker can answer
+// findProviderAndInstanceSizeByIDs-style lookups without re-fetching from
+// Atlas.
+type PlanIndexEntry struct {
+	Provider     *atlas.Provider
+	InstanceSize *atlas.InstanceSize
+}
+
+// RefreshFunc produces the current catalog and plan index. It's expected to
+// call out to Atlas, which is why the cache exists - to avoid doing that on
+// every Services() call.
+type RefreshFunc func() ([]brokerapi.Service, map[string]PlanIndexEntry, error)
+
+var (
+	cacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "broker_catalog_cache_hits_total",
+		Help: "Number of background catalog refreshes served from Atlas successfully.",
+	})
+	cacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "broker_catalog_cache_misses_total",
+		Help: "Number of catalog refreshes that failed, leaving the cache stale or empty.",
+	})
+	cacheAgeSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "broker_catalog_cache_age_seconds",
+		Help: "Age of the currently cached catalog, in seconds.",
+	})
+	refreshFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "broker_catalog_cache_refresh_failures_total",
+		Help: "Number of background catalog refreshes that failed.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(cacheHits, cacheMisses, cacheAgeSeconds, refreshFailures)
+}
+
+// Cache holds the latest catalog generated from Atlas, refreshed
+// periodically by a background goroutine with jittered retry on failure. A
+// refresh failure leaves the previous, still-warm contents in place rather
+// than surfacing an error to callers.
+type Cache struct {
+	refresh  RefreshFunc
+	interval time.Duration
+	logger   *zap.SugaredLogger
+
+	mu          sync.RWMutex
+	services    []brokerapi.Service
+	planIndex   map[string]PlanIndexEntry
+	lastRefresh time.Time
+	warm        bool
+
+	stop chan struct{}
+}
+
+// New creates a Cache, performs an initial synchronous refresh and starts
+// its background refresh goroutine. Call Stop to shut the goroutine down.
+// interval defaults to DefaultRefreshInterval when zero.
+func New(refresh RefreshFunc, interval time.Duration, logger *zap.SugaredLogger) *Cache {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	c := &Cache{
+		refresh:  refresh,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+
+	c.refreshOnce()
+	go c.loop()
+
+	return c
+}
+
+// Stop shuts down the background refresh goroutine.
+func (c *Cache) Stop() {
+	close(c.stop)
+}
+
+// Services returns the cached catalog. It only errors if the cache has
+// never completed a successful refresh; once warm, a later refresh failure
+// just serves the last known-good catalog.
+func (c *Cache) Services() ([]brokerapi.Service, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.warm {
+		return nil, errors.New("catalog cache has not warmed up yet")
+	}
+
+	cacheAgeSeconds.Set(time.Since(c.lastRefresh).Seconds())
+
+	return c.services, nil
+}
+
+// Lookup resolves a plan ID to the provider and instance size it was
+// generated from.
+func (c *Cache) Lookup(planID string) (*atlas.Provider, *atlas.InstanceSize, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.planIndex[planID]
+	if !ok {
+		return nil, nil, false
+	}
+
+	return entry.Provider, entry.InstanceSize, true
+}
+
+func (c *Cache) loop() {
+	for {
+		jitter := time.Duration(rand.Int63n(int64(c.interval)/4 + 1))
+
+		select {
+		case <-time.After(c.interval + jitter):
+			c.refreshOnce()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *Cache) refreshOnce() {
+	services, planIndex, err := c.refresh()
+	if err != nil {
+		refreshFailures.Inc()
+		cacheMisses.Inc()
+
+		if c.logger != nil {
+			c.logger.Errorw("Failed to refresh catalog cache, serving stale copy", "error", err)
+		}
+
+		return
+	}
+
+	c.mu.Lock()
+	c.services = services
+	c.planIndex = planIndex
+	c.lastRefresh = time.Now()
+	c.warm = true
+	c.mu.Unlock()
+
+	cacheHits.Inc()
+}
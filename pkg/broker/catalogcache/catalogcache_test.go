@@ -0,0 +1,89 @@
+package catalogcache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+)
+
+func TestCacheServesWarmCatalog(t *testing.T) {
+	services := []brokerapi.Service{{ID: "service-1"}}
+	planIndex := map[string]PlanIndexEntry{
+		"plan-1": {Provider: &atlas.Provider{Name: "AWS"}, InstanceSize: &atlas.InstanceSize{Name: "M10"}},
+	}
+
+	c := New(func() ([]brokerapi.Service, map[string]PlanIndexEntry, error) {
+		return services, planIndex, nil
+	}, time.Hour, nil)
+	defer c.Stop()
+
+	got, err := c.Services()
+	if err != nil {
+		t.Fatalf("Services() returned unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0].ID != "service-1" {
+		t.Fatalf("Services() = %v, want %v", got, services)
+	}
+}
+
+func TestCacheServicesErrorsBeforeFirstSuccessfulRefresh(t *testing.T) {
+	c := New(func() ([]brokerapi.Service, map[string]PlanIndexEntry, error) {
+		return nil, nil, errors.New("atlas unreachable")
+	}, time.Hour, nil)
+	defer c.Stop()
+
+	if _, err := c.Services(); err == nil {
+		t.Fatal("Services() = nil error, want an error since the cache never warmed up")
+	}
+}
+
+func TestCacheLookup(t *testing.T) {
+	provider := &atlas.Provider{Name: "AWS"}
+	instanceSize := &atlas.InstanceSize{Name: "M10"}
+
+	c := New(func() ([]brokerapi.Service, map[string]PlanIndexEntry, error) {
+		return nil, map[string]PlanIndexEntry{
+			"plan-1": {Provider: provider, InstanceSize: instanceSize},
+		}, nil
+	}, time.Hour, nil)
+	defer c.Stop()
+
+	gotProvider, gotInstanceSize, ok := c.Lookup("plan-1")
+	if !ok || gotProvider != provider || gotInstanceSize != instanceSize {
+		t.Fatalf("Lookup(%q) = (%v, %v, %v), want (%v, %v, true)", "plan-1", gotProvider, gotInstanceSize, ok, provider, instanceSize)
+	}
+
+	if _, _, ok := c.Lookup("unknown-plan"); ok {
+		t.Fatal("Lookup(unknown-plan) = true, want false")
+	}
+}
+
+func TestCacheServesStaleCatalogOnRefreshFailure(t *testing.T) {
+	services := []brokerapi.Service{{ID: "service-1"}}
+	fail := false
+
+	c := New(func() ([]brokerapi.Service, map[string]PlanIndexEntry, error) {
+		if fail {
+			return nil, nil, errors.New("atlas unreachable")
+		}
+
+		return services, nil, nil
+	}, time.Hour, nil)
+	defer c.Stop()
+
+	fail = true
+	c.refreshOnce()
+
+	got, err := c.Services()
+	if err != nil {
+		t.Fatalf("Services() returned unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0].ID != "service-1" {
+		t.Fatalf("Services() = %v, want the stale catalog %v", got, services)
+	}
+}
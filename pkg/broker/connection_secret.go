@@ -0,0 +1,104 @@
+package broker
+
+import (
+	"errors"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// errConnectionSecretUnsupported is returned by Bind when a caller passes a
+// connectionSecret parameter but the broker wasn't configured with a
+// Kubernetes client (see Broker.WithKubeClient).
+var errConnectionSecretUnsupported = errors.New("this broker deployment doesn't support the connectionSecret bind parameter")
+
+// ConnectionSecretParams is the "connectionSecret" bind parameter requesting
+// that credentials and connection strings be delivered as a Kubernetes
+// Secret rather than only in the bind response's Credentials map. It mirrors
+// the ConnectionSecret field on the Atlas Kubernetes operator's
+// AtlasDatabaseUser.
+type ConnectionSecretParams struct {
+	Name string `json:"name"`
+
+	// Namespace defaults to the defaultNamespace passed to
+	// Broker.WithKubeClient when left empty.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ConnectionSecretData is the set of fields written into the generated
+// Secret. Which of Username/Password or Certificate/PrivateKey are
+// populated depends on whether the database user authenticates with
+// SCRAM-SHA-256 or X.509.
+type ConnectionSecretData struct {
+	ConnectionStringStandard string
+	ConnectionStringSRV      string
+	Username                 string
+	Password                 string
+	Certificate              string
+	PrivateKey               string
+}
+
+// connectionSecretParamsFromBindParams extracts ConnectionSecretParams from
+// the raw bind parameters, returning nil if the caller didn't ask for one.
+func connectionSecretParamsFromBindParams(params map[string]interface{}) (*ConnectionSecretParams, error) {
+	raw, ok := params["connectionSecret"]
+	if !ok {
+		return nil, nil
+	}
+
+	secretParams, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("connectionSecret parameter must be an object")
+	}
+
+	name, ok := secretParams["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("connectionSecret.name is required")
+	}
+
+	namespace, _ := secretParams["namespace"].(string)
+
+	return &ConnectionSecretParams{Name: name, Namespace: namespace}, nil
+}
+
+// writeConnectionSecret creates or updates a Kubernetes Secret in the
+// requested namespace holding a bound database user's credentials and
+// connection strings, so a caller that can't read the bind response's
+// Credentials map (e.g. a controller reconciling on the user's behalf) can
+// still consume them.
+func writeConnectionSecret(kubeClient kubernetes.Interface, defaultNamespace string, params ConnectionSecretParams, data ConnectionSecretData) error {
+	namespace := params.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	secretData := map[string][]byte{
+		"connectionStringStandard": []byte(data.ConnectionStringStandard),
+		"connectionStringSrv":      []byte(data.ConnectionStringSRV),
+	}
+
+	if data.Certificate != "" || data.PrivateKey != "" {
+		secretData["certificate"] = []byte(data.Certificate)
+		secretData["privateKey"] = []byte(data.PrivateKey)
+	} else {
+		secretData["username"] = []byte(data.Username)
+		secretData["password"] = []byte(data.Password)
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      params.Name,
+			Namespace: namespace,
+		},
+		Data: secretData,
+	}
+
+	_, err := kubeClient.CoreV1().Secrets(namespace).Create(secret)
+	if k8serrors.IsAlreadyExists(err) {
+		_, err = kubeClient.CoreV1().Secrets(namespace).Update(secret)
+	}
+
+	return err
+}
@@ -0,0 +1,47 @@
+package broker
+
+import "testing"
+
+func TestConnectionSecretParamsFromBindParamsAbsent(t *testing.T) {
+	params, err := connectionSecretParamsFromBindParams(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("connectionSecretParamsFromBindParams returned unexpected error: %v", err)
+	}
+
+	if params != nil {
+		t.Fatalf("connectionSecretParamsFromBindParams() = %v, want nil", params)
+	}
+}
+
+func TestConnectionSecretParamsFromBindParams(t *testing.T) {
+	raw := map[string]interface{}{
+		"connectionSecret": map[string]interface{}{
+			"name":      "my-secret",
+			"namespace": "my-namespace",
+		},
+	}
+
+	params, err := connectionSecretParamsFromBindParams(raw)
+	if err != nil {
+		t.Fatalf("connectionSecretParamsFromBindParams returned unexpected error: %v", err)
+	}
+
+	want := &ConnectionSecretParams{Name: "my-secret", Namespace: "my-namespace"}
+	if *params != *want {
+		t.Fatalf("connectionSecretParamsFromBindParams() = %+v, want %+v", params, want)
+	}
+}
+
+func TestConnectionSecretParamsFromBindParamsMissingName(t *testing.T) {
+	raw := map[string]interface{}{"connectionSecret": map[string]interface{}{"namespace": "my-namespace"}}
+
+	if _, err := connectionSecretParamsFromBindParams(raw); err == nil {
+		t.Fatal("connectionSecretParamsFromBindParams(missing name) = nil error, want an error")
+	}
+}
+
+func TestConnectionSecretParamsFromBindParamsInvalidType(t *testing.T) {
+	if _, err := connectionSecretParamsFromBindParams(map[string]interface{}{"connectionSecret": "not an object"}); err == nil {
+		t.Fatal("connectionSecretParamsFromBindParams(connectionSecret: string) = nil error, want an error")
+	}
+}
@@ -0,0 +1,22 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/pivotal-cf/brokerapi"
+)
+
+// Deprovision tears down the Atlas deployment backing instanceID, using the
+// "/clusters" or "/serverless" endpoint depending on the service class's
+// kind.
+func (b Broker) Deprovision(ctx context.Context, instanceID string, details brokerapi.DeprovisionDetails, asyncAllowed bool) (brokerapi.DeprovisionServiceSpec, error) {
+	if err := b.terminateAtlasResource(details.ServiceID, instanceID); err != nil {
+		return brokerapi.DeprovisionServiceSpec{}, err
+	}
+
+	if b.instanceStore != nil {
+		b.instanceStore.Remove(instanceID)
+	}
+
+	return brokerapi.DeprovisionServiceSpec{IsAsync: true}, nil
+}
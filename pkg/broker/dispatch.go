@@ -0,0 +1,16 @@
+package broker
+
+// terminateAtlasResource deletes the Atlas resource backing instanceID,
+// choosing the "/clusters" or "/serverless" endpoint based on the service
+// class's kind. Dedicated clusters and shared tenant-tier clusters are both
+// deleted through "/clusters"; only serverless instances use a separate
+// endpoint.
+func (b Broker) terminateAtlasResource(serviceID, instanceID string) error {
+	name := atlasNameForInstance(instanceID)
+
+	if ServiceClassKindForID(serviceID) == ServiceClassServerless {
+		return b.atlas.TerminateServerlessInstance(b.groupID, name)
+	}
+
+	return b.atlas.TerminateCluster(b.groupID, name)
+}
@@ -0,0 +1,61 @@
+// Package instancestore provides the default in-memory implementation of
+// broker.InstanceStore, tracking which service and plan ID each
+// provisioned instance was created against.
+package instancestore
+
+import "sync"
+
+// planRef identifies the service and plan an instance was provisioned
+// against.
+type planRef struct {
+	serviceID string
+	planID    string
+}
+
+// Store is a thread-safe, in-memory broker.InstanceStore. It only tracks
+// instances provisioned since the broker process started; it isn't meant
+// as a durable source of truth (service-catalog itself is), only as a
+// cheap way to tell whether a plan dropped from the catalog is still
+// referenced before pruning it.
+type Store struct {
+	mu        sync.Mutex
+	instances map[string]planRef // instanceID -> planRef
+}
+
+// New returns an empty Store.
+func New() *Store {
+	return &Store{instances: make(map[string]planRef)}
+}
+
+// Record associates instanceID with the service and plan it was
+// provisioned against.
+func (s *Store) Record(instanceID, serviceID, planID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.instances[instanceID] = planRef{serviceID: serviceID, planID: planID}
+}
+
+// Remove forgets instanceID, e.g. after it's been deprovisioned.
+func (s *Store) Remove(instanceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.instances, instanceID)
+}
+
+// CountInstancesForPlan returns how many tracked instances currently
+// reference the given service and plan ID.
+func (s *Store) CountInstancesForPlan(serviceID, planID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, ref := range s.instances {
+		if ref.serviceID == serviceID && ref.planID == planID {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,49 @@
+package instancestore
+
+import "testing"
+
+func TestStoreCountInstancesForPlan(t *testing.T) {
+	s := New()
+
+	s.Record("instance-1", "service-a", "plan-1")
+	s.Record("instance-2", "service-a", "plan-1")
+	s.Record("instance-3", "service-a", "plan-2")
+
+	count, err := s.CountInstancesForPlan("service-a", "plan-1")
+	if err != nil {
+		t.Fatalf("CountInstancesForPlan returned unexpected error: %v", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("CountInstancesForPlan(service-a, plan-1) = %d, want 2", count)
+	}
+}
+
+func TestStoreRemove(t *testing.T) {
+	s := New()
+
+	s.Record("instance-1", "service-a", "plan-1")
+	s.Remove("instance-1")
+
+	count, err := s.CountInstancesForPlan("service-a", "plan-1")
+	if err != nil {
+		t.Fatalf("CountInstancesForPlan returned unexpected error: %v", err)
+	}
+
+	if count != 0 {
+		t.Fatalf("CountInstancesForPlan(service-a, plan-1) after Remove = %d, want 0", count)
+	}
+}
+
+func TestStoreCountInstancesForUnknownPlan(t *testing.T) {
+	s := New()
+
+	count, err := s.CountInstancesForPlan("service-a", "plan-1")
+	if err != nil {
+		t.Fatalf("CountInstancesForPlan returned unexpected error: %v", err)
+	}
+
+	if count != 0 {
+		t.Fatalf("CountInstancesForPlan(service-a, plan-1) = %d, want 0", count)
+	}
+}
@@ -0,0 +1,62 @@
+package broker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+)
+
+// atlasStateToLastOperationState maps the Atlas "stateName" field reported
+// for clusters and serverless instances onto the OSB LastOperationState
+// values service-catalog polls for.
+var atlasStateToLastOperationState = map[string]brokerapi.LastOperationState{
+	"IDLE":             brokerapi.Succeeded,
+	"CREATING":         brokerapi.InProgress,
+	"UPDATING":         brokerapi.InProgress,
+	"DELETING":         brokerapi.InProgress,
+	"REPAIRING":        brokerapi.InProgress,
+	"DELETION_FAILED":  brokerapi.Failed,
+	"DELETE_REQUESTED": brokerapi.InProgress,
+}
+
+// LastOperation reports the status of an in-progress provision, update or
+// deprovision by polling the Atlas resource's current state.
+func (b Broker) LastOperation(ctx context.Context, instanceID string, details brokerapi.PollDetails) (brokerapi.LastOperation, error) {
+	name := atlasNameForInstance(instanceID)
+
+	var stateName string
+
+	if ServiceClassKindForID(details.ServiceID) == ServiceClassServerless {
+		instance, err := b.atlas.GetServerlessInstance(b.groupID, name)
+		if err != nil {
+			if atlas.IsNotFound(err) {
+				// A deprovision finished deleting the instance.
+				return brokerapi.LastOperation{State: brokerapi.Succeeded}, nil
+			}
+
+			return brokerapi.LastOperation{}, err
+		}
+
+		stateName = instance.StateName
+	} else {
+		cluster, err := b.atlas.GetCluster(b.groupID, name)
+		if err != nil {
+			if atlas.IsNotFound(err) {
+				return brokerapi.LastOperation{State: brokerapi.Succeeded}, nil
+			}
+
+			return brokerapi.LastOperation{}, err
+		}
+
+		stateName = cluster.StateName
+	}
+
+	state, ok := atlasStateToLastOperationState[stateName]
+	if !ok {
+		return brokerapi.LastOperation{}, errors.New("unrecognized Atlas state " + stateName)
+	}
+
+	return brokerapi.LastOperation{State: state}, nil
+}
@@ -0,0 +1,40 @@
+package broker
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// decodeParams decodes a provision/update/bind request's raw parameters
+// payload into a generic map, so individual features (auditing,
+// connectionSecret, ...) can pull out their own keys without each defining
+// a full parameters struct.
+func decodeParams(raw json.RawMessage) (map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// maxAtlasNameLength is the longest name Atlas accepts for a cluster or
+// serverless instance.
+const maxAtlasNameLength = 23
+
+// atlasNameForInstance derives an Atlas cluster/serverless instance name
+// from an OSB instance ID. Atlas names are more restrictive than instance
+// IDs - shorter, and alphanumeric only - so this strips hyphens and
+// truncates rather than using the ID directly.
+func atlasNameForInstance(instanceID string) string {
+	name := strings.ReplaceAll(instanceID, "-", "")
+	if len(name) > maxAtlasNameLength {
+		name = name[:maxAtlasNameLength]
+	}
+
+	return name
+}
@@ -0,0 +1,63 @@
+package broker
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeParams(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  json.RawMessage
+		want map[string]interface{}
+	}{
+		{name: "empty", raw: nil, want: map[string]interface{}{}},
+		{name: "object", raw: json.RawMessage(`{"auditing":{"enabled":true}}`), want: map[string]interface{}{
+			"auditing": map[string]interface{}{"enabled": true},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeParams(tt.raw)
+			if err != nil {
+				t.Fatalf("decodeParams(%s) returned unexpected error: %v", tt.raw, err)
+			}
+
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+
+			if string(gotJSON) != string(wantJSON) {
+				t.Fatalf("decodeParams(%s) = %s, want %s", tt.raw, gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestDecodeParamsInvalidJSON(t *testing.T) {
+	if _, err := decodeParams(json.RawMessage(`{not valid json`)); err == nil {
+		t.Fatal("decodeParams(invalid JSON) = nil error, want an error")
+	}
+}
+
+func TestAtlasNameForInstance(t *testing.T) {
+	tests := []struct {
+		instanceID string
+		want       string
+	}{
+		{instanceID: "abcd-1234", want: "abcd1234"},
+		{instanceID: "11111111-1111-1111-1111-111111111111", want: "11111111111111111111111"},
+	}
+
+	for _, tt := range tests {
+		got := atlasNameForInstance(tt.instanceID)
+
+		if got != tt.want {
+			t.Errorf("atlasNameForInstance(%q) = %q, want %q", tt.instanceID, got, tt.want)
+		}
+
+		if len(got) > maxAtlasNameLength {
+			t.Errorf("atlasNameForInstance(%q) = %q, longer than maxAtlasNameLength (%d)", tt.instanceID, got, maxAtlasNameLength)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package broker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+)
+
+// Provision creates a new Atlas deployment - a dedicated cluster, a
+// serverless instance, or a shared tenant-tier cluster - depending on which
+// kind of service class was requested, and applies any auditing
+// configuration passed in the provision parameters.
+func (b Broker) Provision(ctx context.Context, instanceID string, details brokerapi.ProvisionDetails, asyncAllowed bool) (brokerapi.ProvisionedServiceSpec, error) {
+	if err := b.ValidateServiceAndPlanIDs(ctx, details.ServiceID, details.PlanID); err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-catalog-reference")
+	}
+
+	params, err := decodeParams(details.RawParameters)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-parameters")
+	}
+
+	auditingSpec, err := auditingSpecFromParams(params)
+	if err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-auditing-parameters")
+	}
+
+	if err := b.createAtlasResource(instanceID, details); err != nil {
+		return brokerapi.ProvisionedServiceSpec{}, err
+	}
+
+	if _, err := b.applyAuditing(b.groupID, auditingSpec); err != nil {
+		// The cluster/instance was already created; don't leave it orphaned
+		// just because auditing couldn't be applied.
+		if terminateErr := b.terminateAtlasResource(details.ServiceID, instanceID); terminateErr != nil {
+			b.logger.Errorw("Failed to clean up instance after auditing failure", "instanceID", instanceID, "error", terminateErr)
+		}
+
+		return brokerapi.ProvisionedServiceSpec{}, err
+	}
+
+	if b.instanceStore != nil {
+		b.instanceStore.Record(instanceID, details.ServiceID, details.PlanID)
+	}
+
+	return brokerapi.ProvisionedServiceSpec{IsAsync: true}, nil
+}
+
+// createAtlasResource provisions the Atlas resource backing instanceID,
+// dispatching to the "/clusters" or "/serverless" endpoint based on the
+// resolved service class.
+func (b Broker) createAtlasResource(instanceID string, details brokerapi.ProvisionDetails) error {
+	name := atlasNameForInstance(instanceID)
+
+	switch ServiceClassKindForID(details.ServiceID) {
+	case ServiceClassServerless:
+		providerName, err := b.findServerlessProviderByIDs(details.ServiceID, details.PlanID)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.atlas.CreateServerlessInstance(b.groupID, atlas.ServerlessInstanceSpec{
+			Name: name,
+			ProviderSettings: atlas.ServerlessProviderSettings{
+				ProviderName:        "SERVERLESS",
+				BackingProviderName: providerName,
+			},
+		})
+
+		return err
+
+	case ServiceClassTenant:
+		providerName, instanceSize, err := b.findTenantInstanceSizeByIDs(details.ServiceID, details.PlanID)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.atlas.CreateCluster(b.groupID, atlas.ClusterSpec{
+			Name: name,
+			ProviderSettings: atlas.ClusterProviderSettings{
+				ProviderName:     providerName,
+				InstanceSizeName: instanceSize,
+			},
+		})
+
+		return err
+
+	default:
+		provider, instanceSize, err := b.findProviderAndInstanceSizeByIDs(details.ServiceID, details.PlanID)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.atlas.CreateCluster(b.groupID, atlas.ClusterSpec{
+			Name: name,
+			ProviderSettings: atlas.ClusterProviderSettings{
+				ProviderName:     provider.Name,
+				InstanceSizeName: instanceSize.Name,
+			},
+		})
+
+		return err
+	}
+}
@@ -0,0 +1,16 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/pivotal-cf/brokerapi"
+)
+
+// Unbind deletes the Atlas database user created for bindingID.
+func (b Broker) Unbind(ctx context.Context, instanceID, bindingID string, details brokerapi.UnbindDetails, asyncAllowed bool) (brokerapi.UnbindSpec, error) {
+	if err := b.atlas.DeleteDatabaseUser(b.groupID, bindDatabaseName, bindingID); err != nil {
+		return brokerapi.UnbindSpec{}, err
+	}
+
+	return brokerapi.UnbindSpec{}, nil
+}
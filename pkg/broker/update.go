@@ -0,0 +1,94 @@
+package broker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/pivotal-cf/brokerapi"
+)
+
+// Update applies a plan change and/or auditing configuration change to an
+// existing dedicated or shared tenant-tier cluster. Serverless instances
+// don't have a plan to change, so PlanUpdatable is false for them and an
+// update only applies their auditing configuration.
+func (b Broker) Update(ctx context.Context, instanceID string, details brokerapi.UpdateDetails, asyncAllowed bool) (brokerapi.UpdateServiceSpec, error) {
+	if err := b.ValidateServiceAndPlanIDs(ctx, details.ServiceID, details.PlanID); err != nil {
+		return brokerapi.UpdateServiceSpec{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-catalog-reference")
+	}
+
+	params, err := decodeParams(details.RawParameters)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-parameters")
+	}
+
+	auditingSpec, err := auditingSpecFromParams(params)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, brokerapi.NewFailureResponse(err, http.StatusBadRequest, "invalid-auditing-parameters")
+	}
+
+	rollback, err := b.applyAuditing(b.groupID, auditingSpec)
+	if err != nil {
+		return brokerapi.UpdateServiceSpec{}, err
+	}
+
+	if err := b.updateAtlasResource(instanceID, details); err != nil {
+		if rollbackErr := rollback(); rollbackErr != nil {
+			b.logger.Errorw("Failed to roll back auditing after update failure", "instanceID", instanceID, "error", rollbackErr)
+		}
+
+		return brokerapi.UpdateServiceSpec{}, err
+	}
+
+	if b.instanceStore != nil {
+		b.instanceStore.Record(instanceID, details.ServiceID, details.PlanID)
+	}
+
+	return brokerapi.UpdateServiceSpec{IsAsync: true}, nil
+}
+
+// updateAtlasResource applies a plan change to the cluster backing
+// instanceID. Tenant clusters go through the same "/clusters" endpoint as
+// dedicated ones, just with a reserved instance size name.
+func (b Broker) updateAtlasResource(instanceID string, details brokerapi.UpdateDetails) error {
+	name := atlasNameForInstance(instanceID)
+
+	switch ServiceClassKindForID(details.ServiceID) {
+	case ServiceClassServerless:
+		// Serverless instances have a single plan, so there's nothing to
+		// change in Atlas.
+		return nil
+
+	case ServiceClassTenant:
+		providerName, instanceSize, err := b.findTenantInstanceSizeByIDs(details.ServiceID, details.PlanID)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.atlas.UpdateCluster(b.groupID, name, atlas.ClusterSpec{
+			Name: name,
+			ProviderSettings: atlas.ClusterProviderSettings{
+				ProviderName:     providerName,
+				InstanceSizeName: instanceSize,
+			},
+		})
+
+		return err
+
+	default:
+		provider, instanceSize, err := b.findProviderAndInstanceSizeByIDs(details.ServiceID, details.PlanID)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.atlas.UpdateCluster(b.groupID, name, atlas.ClusterSpec{
+			Name: name,
+			ProviderSettings: atlas.ClusterProviderSettings{
+				ProviderName:     provider.Name,
+				InstanceSizeName: instanceSize.Name,
+			},
+		})
+
+		return err
+	}
+}
@@ -0,0 +1,35 @@
+// +build contract
+
+package contract
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditingRoundTrip verifies that an audit filter written through
+// Client.SetAuditing is returned unchanged by Client.GetAuditing. Atlas only
+// validates the audit filter JSON server-side, so this can't be exercised
+// against a fake - it needs a real Atlas project.
+func TestAuditingRoundTrip(t *testing.T) {
+	t.Parallel()
+	skipUnlessEnabled(t, "auditing")
+
+	h := NewHarness()
+
+	spec := atlas.AuditingSpec{
+		Enabled:                   true,
+		AuditFilter:               `{"atype": "authenticate"}`,
+		AuditAuthorizationSuccess: true,
+	}
+
+	require.NoError(t, h.Atlas.SetAuditing(h.GroupID, spec))
+
+	got, err := h.Atlas.GetAuditing(h.GroupID)
+	require.NoError(t, err)
+
+	assert.Equal(t, spec, *got)
+}
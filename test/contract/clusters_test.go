@@ -0,0 +1,63 @@
+// +build contract
+
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDedicatedClusterLifecycle provisions, binds and deprovisions a
+// dedicated cluster against a real Atlas project through the broker's
+// brokerapi handlers directly.
+func TestDedicatedClusterLifecycle(t *testing.T) {
+	t.Parallel()
+	skipUnlessEnabled(t, "clusters")
+
+	h := NewHarness()
+	ctx := context.Background()
+
+	services, err := h.Broker.Services(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, services)
+
+	service := services[0]
+	require.NotEmpty(t, service.Plans)
+	plan := service.Plans[0]
+
+	instanceID := uuid.New().String()
+
+	_, err = h.Broker.Provision(ctx, instanceID, brokerapi.ProvisionDetails{
+		ServiceID:        service.ID,
+		PlanID:           plan.ID,
+		OrganizationGUID: h.GroupID,
+	}, true)
+	require.NoError(t, err)
+
+	defer func() {
+		_, err := h.Broker.Deprovision(ctx, instanceID, brokerapi.DeprovisionDetails{
+			ServiceID: service.ID,
+			PlanID:    plan.ID,
+		}, true)
+		require.NoError(t, err)
+	}()
+
+	bindingID := uuid.New().String()
+
+	binding, err := h.Broker.Bind(ctx, instanceID, bindingID, brokerapi.BindDetails{
+		ServiceID: service.ID,
+		PlanID:    plan.ID,
+	}, true)
+	require.NoError(t, err)
+	require.NotEmpty(t, binding.Credentials)
+
+	_, err = h.Broker.Unbind(ctx, instanceID, bindingID, brokerapi.UnbindDetails{
+		ServiceID: service.ID,
+		PlanID:    plan.ID,
+	}, true)
+	require.NoError(t, err)
+}
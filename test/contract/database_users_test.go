@@ -0,0 +1,109 @@
+// +build contract
+
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDatabaseUserCredentialsReturned provisions a cluster, binds it and
+// verifies the binding returns a SCRAM-SHA-256 username and password.
+func TestDatabaseUserCredentialsReturned(t *testing.T) {
+	t.Parallel()
+	skipUnlessEnabled(t, "database_users")
+
+	h := NewHarness()
+	ctx := context.Background()
+
+	services, err := h.Broker.Services(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, services)
+
+	service := services[0]
+	require.NotEmpty(t, service.Plans)
+	plan := service.Plans[0]
+
+	instanceID := uuid.New().String()
+
+	_, err = h.Broker.Provision(ctx, instanceID, brokerapi.ProvisionDetails{
+		ServiceID:        service.ID,
+		PlanID:           plan.ID,
+		OrganizationGUID: h.GroupID,
+	}, true)
+	require.NoError(t, err)
+
+	defer func() {
+		_, err := h.Broker.Deprovision(ctx, instanceID, brokerapi.DeprovisionDetails{
+			ServiceID: service.ID,
+			PlanID:    plan.ID,
+		}, true)
+		require.NoError(t, err)
+	}()
+
+	bindingID := uuid.New().String()
+
+	binding, err := h.Broker.Bind(ctx, instanceID, bindingID, brokerapi.BindDetails{
+		ServiceID: service.ID,
+		PlanID:    plan.ID,
+	}, true)
+	require.NoError(t, err)
+	require.Contains(t, binding.Credentials, "username")
+	require.Contains(t, binding.Credentials, "password")
+}
+
+// TestDatabaseUserX509Binding provisions a cluster and binds it with
+// authMode "x509", verifying the broker returns an Atlas-issued
+// certificate instead of a SCRAM-SHA-256 username/password pair.
+func TestDatabaseUserX509Binding(t *testing.T) {
+	t.Parallel()
+	skipUnlessEnabled(t, "database_users")
+
+	h := NewHarness()
+	ctx := context.Background()
+
+	services, err := h.Broker.Services(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, services)
+
+	service := services[0]
+	require.NotEmpty(t, service.Plans)
+	plan := service.Plans[0]
+
+	instanceID := uuid.New().String()
+
+	_, err = h.Broker.Provision(ctx, instanceID, brokerapi.ProvisionDetails{
+		ServiceID:        service.ID,
+		PlanID:           plan.ID,
+		OrganizationGUID: h.GroupID,
+	}, true)
+	require.NoError(t, err)
+
+	defer func() {
+		_, err := h.Broker.Deprovision(ctx, instanceID, brokerapi.DeprovisionDetails{
+			ServiceID: service.ID,
+			PlanID:    plan.ID,
+		}, true)
+		require.NoError(t, err)
+	}()
+
+	bindingID := uuid.New().String()
+
+	rawParams, err := json.Marshal(map[string]interface{}{"authMode": "x509"})
+	require.NoError(t, err)
+
+	binding, err := h.Broker.Bind(ctx, instanceID, bindingID, brokerapi.BindDetails{
+		ServiceID:     service.ID,
+		PlanID:        plan.ID,
+		RawParameters: rawParams,
+	}, true)
+	require.NoError(t, err)
+	require.Contains(t, binding.Credentials, "certificate")
+	require.Contains(t, binding.Credentials, "privateKey")
+	require.NotContains(t, binding.Credentials, "password")
+}
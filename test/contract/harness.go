@@ -0,0 +1,41 @@
+// +build contract
+
+// Package contract runs the broker's brokerapi handlers directly against a
+// real Atlas project, without deploying anything into Kubernetes. It's
+// meant as a faster, more focused alternative to test/e2e for validating
+// Atlas API compatibility - see test/e2e's TestMain for the heavier
+// kube-apiserver + service-catalog setup this avoids.
+package contract
+
+import (
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/atlas"
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/broker"
+	testutil "github.com/mongodb/mongodb-atlas-service-broker/test/util"
+	"github.com/pivotal-cf/brokerapi"
+	"go.uber.org/zap"
+)
+
+// Harness stands up a broker in-process and exposes its brokerapi.ServiceBroker
+// directly to contract tests.
+type Harness struct {
+	Broker  brokerapi.ServiceBroker
+	Atlas   *atlas.Client
+	GroupID string
+}
+
+// NewHarness builds a Harness backed by a real Atlas client, configured from
+// the ATLAS_PUBLIC_KEY, ATLAS_PRIVATE_KEY and ATLAS_GROUP_ID environment
+// variables.
+func NewHarness() *Harness {
+	groupID := testutil.GetEnvOrPanic("ATLAS_GROUP_ID")
+	publicKey := testutil.GetEnvOrPanic("ATLAS_PUBLIC_KEY")
+	privateKey := testutil.GetEnvOrPanic("ATLAS_PRIVATE_KEY")
+
+	atlasClient := atlas.NewClient(publicKey, privateKey)
+
+	return &Harness{
+		Broker:  broker.NewBroker(atlasClient, groupID, zap.NewNop().Sugar(), 0),
+		Atlas:   atlasClient,
+		GroupID: groupID,
+	}
+}
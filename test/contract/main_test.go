@@ -0,0 +1,29 @@
+// +build contract
+
+package contract
+
+import (
+	"os"
+	"testing"
+)
+
+var manifest *Manifest
+
+func TestMain(m *testing.M) {
+	var err error
+
+	manifest, err = LoadManifest("test.yml")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// skipUnlessEnabled skips the calling test unless its feature is enabled in
+// test.yml.
+func skipUnlessEnabled(t *testing.T, feature string) {
+	if !manifest.FeatureEnabled(feature) {
+		t.Skipf("feature %q disabled in test.yml", feature)
+	}
+}
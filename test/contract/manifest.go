@@ -0,0 +1,47 @@
+// +build contract
+
+package contract
+
+import (
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest selects which per-feature contract tests should run. It lets
+// contributors iterating on a single Atlas API (e.g. serverless) skip the
+// others without commenting out test files.
+type Manifest struct {
+	Features []struct {
+		Name    string `yaml:"name"`
+		Enabled bool   `yaml:"enabled"`
+	} `yaml:"features"`
+}
+
+// LoadManifest reads a test.yml manifest from disk.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// FeatureEnabled reports whether the named feature's contract tests should
+// run. Features absent from the manifest are disabled by default, so a new
+// feature added here must be opted into explicitly.
+func (m *Manifest) FeatureEnabled(name string) bool {
+	for _, feature := range m.Features {
+		if feature.Name == name {
+			return feature.Enabled
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,18 @@
+// +build contract
+
+package contract
+
+import (
+	"testing"
+)
+
+// TestNetworkPeeringRoundTrip is a placeholder for network peering contract
+// coverage. The broker doesn't expose network peering as a bindable feature
+// yet, so there's no Atlas client method or broker code path to exercise
+// here. It's listed in test.yml so the feature can be enabled and filled in
+// once peering support lands, rather than the manifest silently omitting it.
+func TestNetworkPeeringRoundTrip(t *testing.T) {
+	skipUnlessEnabled(t, "network_peering")
+
+	t.Skip("network peering isn't implemented by the broker yet")
+}
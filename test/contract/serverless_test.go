@@ -0,0 +1,59 @@
+// +build contract
+
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mongodb/mongodb-atlas-service-broker/pkg/broker"
+	"github.com/pivotal-cf/brokerapi"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServerlessInstanceLifecycle provisions and deprovisions a serverless
+// instance against a real Atlas project, exercising the "/serverless" Atlas
+// endpoint rather than "/clusters".
+func TestServerlessInstanceLifecycle(t *testing.T) {
+	t.Parallel()
+	skipUnlessEnabled(t, "serverless")
+
+	h := NewHarness()
+	ctx := context.Background()
+
+	services, err := h.Broker.Services(ctx)
+	require.NoError(t, err)
+
+	service := serviceOfKind(t, services, broker.ServiceClassServerless)
+	require.NotEmpty(t, service.Plans)
+	plan := service.Plans[0]
+
+	instanceID := uuid.New().String()
+
+	_, err = h.Broker.Provision(ctx, instanceID, brokerapi.ProvisionDetails{
+		ServiceID:        service.ID,
+		PlanID:           plan.ID,
+		OrganizationGUID: h.GroupID,
+	}, true)
+	require.NoError(t, err)
+
+	_, err = h.Broker.Deprovision(ctx, instanceID, brokerapi.DeprovisionDetails{
+		ServiceID: service.ID,
+		PlanID:    plan.ID,
+	}, true)
+	require.NoError(t, err)
+}
+
+// serviceOfKind finds the first service of the given kind, failing the test
+// if none was advertised.
+func serviceOfKind(t *testing.T, services []brokerapi.Service, kind broker.ServiceClassKind) brokerapi.Service {
+	for _, service := range services {
+		if broker.ServiceClassKindForID(service.ID) == kind {
+			return service
+		}
+	}
+
+	t.Fatalf("no service of kind %v in catalog", kind)
+	return brokerapi.Service{}
+}
@@ -1,6 +1,8 @@
 package e2e
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -10,9 +12,13 @@ import (
 	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset"
 	testutil "github.com/mongodb/mongodb-atlas-service-broker/test/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -91,6 +97,125 @@ func TestCatalog(t *testing.T) {
 	assert.NotEmpty(t, plans.Items, "Expected service plans to exist")
 }
 
+// TestBindConnectionSecret binds an instance with a connectionSecret
+// parameter and verifies that the resulting Secret both exists in the
+// requesting namespace and holds credentials that actually connect to
+// Atlas.
+func TestBindConnectionSecret(t *testing.T) {
+	t.Parallel()
+
+	namespace := setupTest(t)
+	defer cleanupTest(t)
+
+	instance := createServiceInstance(t, namespace)
+	secretName := instance.Name + "-connection"
+
+	params, err := json.Marshal(map[string]interface{}{
+		"connectionSecret": map[string]interface{}{
+			"name": secretName,
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-binding"},
+		Spec: v1beta1.ServiceBindingSpec{
+			InstanceRef: v1beta1.LocalObjectReference{Name: instance.Name},
+			Parameters:  &runtime.RawExtension{Raw: params},
+		},
+	}
+
+	_, err = svcatClient.ServicecatalogV1beta1().ServiceBindings(namespace).Create(binding)
+	if !assert.NoError(t, err, "Expected binding request to succeed") {
+		return
+	}
+
+	err = testutil.Poll(10, func() (bool, error) {
+		b, err := svcatClient.ServicecatalogV1beta1().ServiceBindings(namespace).Get(binding.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return b.Status.AsyncOpInProgress == false && len(b.Status.Conditions) > 0, nil
+	})
+	assert.NoError(t, err, "Expected binding to complete")
+
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if !assert.NoError(t, err, "Expected connection secret to have been written") {
+		return
+	}
+
+	connectionString := string(secret.Data["connectionStringStandard"])
+	if !assert.NotEmpty(t, connectionString) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.DefaultTimeout)
+	defer cancel()
+
+	clientOptions := options.Client().ApplyURI(connectionString).SetAuth(options.Credential{
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	})
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	require.NoError(t, err)
+	defer client.Disconnect(ctx)
+
+	assert.NoError(t, client.Ping(ctx, nil), "Expected to connect to Atlas using the connection secret's credentials")
+}
+
+// TestBindX509 binds an instance with authMode "x509" and verifies the
+// resulting binding secret holds a non-empty certificate and private key.
+func TestBindX509(t *testing.T) {
+	t.Parallel()
+
+	namespace := setupTest(t)
+	defer cleanupTest(t)
+
+	instance := createServiceInstance(t, namespace)
+	secretName := instance.Name + "-x509-binding"
+
+	params, err := json.Marshal(map[string]interface{}{"authMode": "x509"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName},
+		Spec: v1beta1.ServiceBindingSpec{
+			InstanceRef: v1beta1.LocalObjectReference{Name: instance.Name},
+			SecretName:  secretName,
+			Parameters:  &runtime.RawExtension{Raw: params},
+		},
+	}
+
+	_, err = svcatClient.ServicecatalogV1beta1().ServiceBindings(namespace).Create(binding)
+	if !assert.NoError(t, err, "Expected binding request to succeed") {
+		return
+	}
+
+	err = testutil.Poll(10, func() (bool, error) {
+		b, err := svcatClient.ServicecatalogV1beta1().ServiceBindings(namespace).Get(binding.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return b.Status.AsyncOpInProgress == false && len(b.Status.Conditions) > 0, nil
+	})
+	assert.NoError(t, err, "Expected binding to complete")
+
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if !assert.NoError(t, err, "Expected binding secret to have been written") {
+		return
+	}
+
+	assert.NotEmpty(t, secret.Data["certificate"])
+	assert.NotEmpty(t, secret.Data["privateKey"])
+}
+
 // setupTest will create a new namespace for a single test and deploy the
 // broker inside.
 func setupTest(t *testing.T) string {
@@ -126,6 +251,48 @@ func cleanupTest(t *testing.T) {
 	}
 }
 
+// createServiceInstance provisions a ServiceInstance against the first
+// service class and plan the broker advertises, and waits for it to become
+// ready.
+func createServiceInstance(t *testing.T, namespace string) *v1beta1.ServiceInstance {
+	classes, err := svcatClient.ServicecatalogV1beta1().ServiceClasses(namespace).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, classes.Items, "Expected at least one service class to provision against")
+
+	class := classes.Items[0]
+
+	plans, err := svcatClient.ServicecatalogV1beta1().ServicePlans(namespace).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, plans.Items, "Expected at least one service plan to provision against")
+
+	plan := plans.Items[0]
+
+	instance := &v1beta1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: namespaceForTest(t) + "-instance"},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ServiceClassExternalName: class.Spec.ExternalName,
+				ServicePlanExternalName:  plan.Spec.ExternalName,
+			},
+		},
+	}
+
+	instance, err = svcatClient.ServicecatalogV1beta1().ServiceInstances(namespace).Create(instance)
+	require.NoError(t, err)
+
+	err = testutil.Poll(10, func() (bool, error) {
+		i, err := svcatClient.ServicecatalogV1beta1().ServiceInstances(namespace).Get(instance.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return i.Status.AsyncOpInProgress == false && len(i.Status.Conditions) > 0, nil
+	})
+	require.NoError(t, err, "Expected service instance to become ready")
+
+	return instance
+}
+
 // namespaceForTest will return a namespace name based on the current test.
 func namespaceForTest(t *testing.T) string {
 	return fmt.Sprintf("aosb-e2e-%s", strings.ToLower(t.Name()))